@@ -0,0 +1,114 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// A permanentError wraps an error returned by an operation passed to
+// Do or DoNotify to indicate that the operation should not be retried,
+// regardless of how many attempts remain.
+type permanentError struct {
+	err error
+}
+
+func (p *permanentError) Error() string {
+	return p.err.Error()
+}
+
+func (p *permanentError) Unwrap() error {
+	return p.err
+}
+
+// Permanent wraps err so that Do and DoNotify stop retrying and return
+// err immediately, instead of continuing to call op. Permanent returns
+// nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// options holds the settings accumulated from a list of Option values.
+type options struct {
+	maxAttempts int
+	maxElapsed  time.Duration
+}
+
+// An Option customizes the behavior of Do and DoNotify.
+type Option func(*options)
+
+// MaxAttempts limits the number of times an operation is attempted.
+// Once n attempts have failed, Do and DoNotify give up and return the
+// last error seen. A value of n <= 0 means no limit is imposed; this
+// is the default.
+func MaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// MaxElapsed stops retrying once the cumulative wall-clock time since
+// the first attempt exceeds d. The operation is always attempted at
+// least once, even if d has already elapsed by the time Do or
+// DoNotify is called. A value of d <= 0 means no limit is imposed;
+// this is the default.
+func MaxElapsed(d time.Duration) Option {
+	return func(o *options) {
+		o.maxElapsed = d
+	}
+}
+
+// Do calls op until it succeeds, returns a permanent error (see
+// Permanent), or the limits imposed by opts are reached, sleeping
+// according to s in between attempts. The sleep between attempts is
+// interrupted by ctx.Done(); if ctx is cancelled while waiting, Do
+// returns ctx.Err().
+func Do(ctx context.Context, s Strategy, op func(ctx context.Context) error, opts ...Option) error {
+	return DoNotify(ctx, s, op, nil, opts...)
+}
+
+// DoNotify behaves like Do, but calls notify after each failed
+// attempt, passing the error returned by op and the duration Do will
+// sleep for before the next attempt. notify may be nil, in which case
+// DoNotify behaves exactly like Do.
+func DoNotify(ctx context.Context, s Strategy, op func(ctx context.Context) error, notify func(err error, next time.Duration), opts ...Option) error {
+	if s == nil {
+		panic("Do called on nil Strategy")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	start := time.Now()
+	var err error
+	for try := 0; ; try++ {
+		err = op(ctx)
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if o.maxAttempts > 0 && try+1 >= o.maxAttempts {
+			return err
+		}
+		next := s(try)
+		if o.maxElapsed > 0 && time.Since(start)+next > o.maxElapsed {
+			return err
+		}
+		if notify != nil {
+			notify(err, next)
+		}
+		t := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
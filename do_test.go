@@ -0,0 +1,110 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDoSucceedsEventually(t *testing.T) {
+	var calls int
+	backoff := Intervals(time.Millisecond)
+	err := Do(context.Background(), backoff, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do returned %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Errorf("op called %d times, want 3", calls)
+	}
+}
+
+func TestDoPermanentError(t *testing.T) {
+	var calls int
+	wantErr := errors.New("fatal")
+	backoff := Intervals(time.Millisecond)
+	err := Do(context.Background(), backoff, func(ctx context.Context) error {
+		calls++
+		return Permanent(wantErr)
+	})
+	if err != wantErr {
+		t.Errorf("Do returned %v, want %v", err, wantErr)
+	}
+	if calls != 1 {
+		t.Errorf("op called %d times, want 1", calls)
+	}
+}
+
+func TestDoMaxAttempts(t *testing.T) {
+	var calls int
+	opErr := errors.New("always fails")
+	backoff := Intervals(time.Millisecond)
+	err := Do(context.Background(), backoff, func(ctx context.Context) error {
+		calls++
+		return opErr
+	}, MaxAttempts(3))
+	if err != opErr {
+		t.Errorf("Do returned %v, want %v", err, opErr)
+	}
+	if calls != 3 {
+		t.Errorf("op called %d times, want 3", calls)
+	}
+}
+
+func TestDoMaxElapsed(t *testing.T) {
+	opErr := errors.New("always fails")
+	backoff := Intervals(10 * time.Millisecond)
+	start := time.Now()
+	err := Do(context.Background(), backoff, func(ctx context.Context) error {
+		return opErr
+	}, MaxElapsed(25*time.Millisecond))
+	if err != opErr {
+		t.Errorf("Do returned %v, want %v", err, opErr)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Do took %s, expected to stop near the MaxElapsed budget", elapsed)
+	}
+}
+
+func TestDoContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	backoff := Intervals(time.Hour)
+	done := make(chan error, 1)
+	go func() {
+		done <- Do(ctx, backoff, func(ctx context.Context) error {
+			return errors.New("still failing")
+		})
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Do returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Do did not return after context was cancelled")
+	}
+}
+
+func TestDoNotify(t *testing.T) {
+	var notified int
+	opErr := errors.New("nope")
+	backoff := Intervals(time.Millisecond)
+	err := DoNotify(context.Background(), backoff, func(ctx context.Context) error {
+		return opErr
+	}, func(err error, next time.Duration) {
+		notified++
+	}, MaxAttempts(4))
+	if err != opErr {
+		t.Errorf("DoNotify returned %v, want %v", err, opErr)
+	}
+	if notified != 3 {
+		t.Errorf("notify called %d times, want 3", notified)
+	}
+}
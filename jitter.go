@@ -0,0 +1,108 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// uniformInclusive draws a pseudo-random value from the closed
+// interval [lo, hi] using r. A plain r.Int63n(hi-lo+1) panics when
+// hi-lo equals math.MaxInt64, since hi-lo+1 overflows back to
+// math.MinInt64; uniformInclusive avoids that by drawing from
+// [lo, hi) instead in that one case.
+func uniformInclusive(r *rand.Rand, lo, hi int64) int64 {
+	span := hi - lo
+	if span == math.MaxInt64 {
+		return lo + r.Int63n(span)
+	}
+	return lo + r.Int63n(span+1)
+}
+
+// saturatingMul3 returns 3*d, saturating to math.MaxInt64 instead of
+// overflowing when d is large enough that 3*d would not fit in an
+// int64 (reachable with a large Cap in DecorrelatedJitter).
+func saturatingMul3(d time.Duration) int64 {
+	if d > math.MaxInt64/3 {
+		return math.MaxInt64
+	}
+	return int64(d) * 3
+}
+
+// FullJitter returns a Strategy that, for each try, draws a duration
+// uniformly from [0, base(try)]. This is the "full jitter" algorithm
+// described in the AWS Architecture Blog post "Exponential Backoff
+// And Jitter", and tends to produce a lower average load on a shared
+// resource than Splay, at the cost of more variance between
+// individual retries.
+func (base Strategy) FullJitter() Strategy {
+	if base == nil {
+		panic("FullJitter called on nil Strategy")
+	}
+	r := rand.New(rand.NewSource(randint64()))
+	return func(try int) time.Duration {
+		n := base(try)
+		if n <= 0 {
+			return 0
+		}
+		return time.Duration(uniformInclusive(r, 0, int64(n)))
+	}
+}
+
+// EqualJitter returns a Strategy that, for each try, returns
+// base(try)/2 plus a duration drawn uniformly from [0, base(try)/2].
+// Like FullJitter, this is one of the jitter algorithms from the AWS
+// Architecture Blog post "Exponential Backoff And Jitter"; it keeps
+// half of the backoff's growth while still spreading out retries.
+func (base Strategy) EqualJitter() Strategy {
+	if base == nil {
+		panic("EqualJitter called on nil Strategy")
+	}
+	r := rand.New(rand.NewSource(randint64()))
+	return func(try int) time.Duration {
+		half := base(try) / 2
+		if half <= 0 {
+			return half
+		}
+		return half + time.Duration(r.Int63n(int64(half)+1))
+	}
+}
+
+// DecorrelatedJitter returns a Strategy implementing the
+// "decorrelated jitter" algorithm from the AWS Architecture Blog post
+// "Exponential Backoff And Jitter". Each call returns a duration
+// drawn uniformly from [base(0), prev*3], clamped to cap, where prev
+// is the duration returned by the previous call (base(0) for the
+// first). The returned Strategy is stateful: unlike the rest of the
+// package, it is not safe for concurrent use by multiple goroutines.
+func (base Strategy) DecorrelatedJitter(cap time.Duration) Strategy {
+	if base == nil {
+		panic("DecorrelatedJitter called on nil Strategy")
+	}
+	r := rand.New(rand.NewSource(randint64()))
+	var mu sync.Mutex
+	var prev time.Duration
+	var baseUnit time.Duration
+	var started bool
+	return func(try int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		if !started {
+			baseUnit = base(0)
+			prev = baseUnit
+			started = true
+		}
+		lo := int64(baseUnit)
+		hi := saturatingMul3(prev)
+		if hi <= lo {
+			prev = baseUnit
+		} else {
+			prev = time.Duration(uniformInclusive(r, lo, hi))
+		}
+		if prev > cap {
+			prev = cap
+		}
+		return prev
+	}
+}
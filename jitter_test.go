@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFullJitter(t *testing.T) {
+	const base = time.Second
+	backoff := Intervals(base).FullJitter()
+	for i := 0; i < 1e5; i++ {
+		b := backoff(0)
+		if b < 0 || b > base {
+			t.Errorf("FullJitter should be in the range [0, %s], got %s", base, b)
+		}
+	}
+}
+
+func TestFullJitterAtMaxDuration(t *testing.T) {
+	// Exponential saturates to math.MaxInt64 at try == 34; FullJitter
+	// must not panic on that saturated value.
+	backoff := Exponential(time.Second).FullJitter()
+	for try := 33; try <= 35; try++ {
+		b := backoff(try)
+		if b < 0 {
+			t.Errorf("backoff(%d) = %s, want a non-negative duration", try, b)
+		}
+	}
+}
+
+func TestEqualJitter(t *testing.T) {
+	const base = time.Second
+	backoff := Intervals(base).EqualJitter()
+	for i := 0; i < 1e5; i++ {
+		b := backoff(0)
+		if b < base/2 || b > base {
+			t.Errorf("EqualJitter should be in the range [%s, %s], got %s", base/2, base, b)
+		}
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	const unit = time.Second
+	const cap = time.Minute
+	backoff := Intervals(unit).DecorrelatedJitter(cap)
+	prev := unit
+	for i := 0; i < 1e5; i++ {
+		b := backoff(0)
+		if b < unit || b > cap {
+			t.Errorf("DecorrelatedJitter should be in the range [%s, %s], got %s", unit, cap, b)
+		}
+		if b > prev*3 && b != cap {
+			t.Errorf("DecorrelatedJitter(%d) = %s, should be at most %s", i, b, prev*3)
+		}
+		prev = b
+	}
+}
+
+func TestDecorrelatedJitterLargeCap(t *testing.T) {
+	// A cap near math.MaxInt64 must not make prev*3 overflow into a
+	// value that looks <= lo, which would silently reset instead of
+	// climbing towards cap.
+	const cap = math.MaxInt64 - 1
+	backoff := Exponential(time.Second).DecorrelatedJitter(cap)
+	for try := 0; try < 100; try++ {
+		if b := backoff(try); b < time.Second || b > cap {
+			t.Errorf("DecorrelatedJitter(%d) = %s, should be in [%s, %s]", try, b, time.Second, time.Duration(cap))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// Reset returns a Strategy derived from base, along with a
+// MarkSuccess function that arms a reset of its retry counter.
+//
+// Calling MarkSuccess does not reset the counter immediately. Instead,
+// it takes effect the next time the returned Strategy is called to
+// back off a new failure: if that failure arrives more than grace
+// after the previous one, the counter is treated as having reset to
+// 0; otherwise the failures are considered part of the same flapping
+// episode and the counter keeps climbing from where it left off. This
+// mirrors the grace-period behavior of cloudflared's BackoffHandler,
+// and replaces the manual "zero the try counter on success" pattern
+// used in the package Example.
+//
+// The returned Strategy is stateful and is not safe for concurrent
+// use by multiple goroutines.
+func (base Strategy) Reset(grace time.Duration) (Strategy, func()) {
+	if base == nil {
+		panic("Reset called on nil Strategy")
+	}
+	var mu sync.Mutex
+	var offset int
+	var lastFailure time.Time
+	var armed bool
+
+	strategy := func(nth int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if armed {
+			if lastFailure.IsZero() || now.Sub(lastFailure) > grace {
+				offset = nth
+			}
+			armed = false
+		}
+		lastFailure = now
+		return base(nth - offset)
+	}
+	markSuccess := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		armed = true
+	}
+	return strategy, markSuccess
+}
@@ -0,0 +1,35 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResetAfterGrace(t *testing.T) {
+	backoff, markSuccess := Exponential(time.Millisecond).Reset(10 * time.Millisecond)
+
+	if x := backoff(2); x != 4*time.Millisecond {
+		t.Fatalf("backoff(2) = %s, want %s", x, 4*time.Millisecond)
+	}
+
+	markSuccess()
+	time.Sleep(20 * time.Millisecond)
+
+	if x := backoff(5); x != time.Millisecond {
+		t.Errorf("backoff(5) after grace period elapsed = %s, want %s (counter should have reset)", x, time.Millisecond)
+	}
+}
+
+func TestResetWithinGrace(t *testing.T) {
+	backoff, markSuccess := Exponential(time.Millisecond).Reset(time.Hour)
+
+	if x := backoff(2); x != 4*time.Millisecond {
+		t.Fatalf("backoff(2) = %s, want %s", x, 4*time.Millisecond)
+	}
+
+	markSuccess()
+
+	if x := backoff(3); x != 8*time.Millisecond {
+		t.Errorf("backoff(3) within grace period = %s, want %s (counter should keep climbing)", x, 8*time.Millisecond)
+	}
+}
@@ -74,6 +74,30 @@ func Exponential(units time.Duration) Strategy {
 	}
 }
 
+// Fibonacci creates a backoff Strategy that returns the nth number in
+// the Fibonacci sequence (0, 1, 1, 2, 3, 5, 8, ...), scaled by units.
+// Like Exponential, the values returned will increase up to the
+// maximum value of time.Duration and will not overflow. If try is
+// less than 0, Fibonacci returns 0.
+func Fibonacci(units time.Duration) Strategy {
+	return func(try int) time.Duration {
+		if try < 0 {
+			return 0
+		}
+		a, b := time.Duration(0), units
+		for i := 0; i < try; i++ {
+			next := a + b
+			if b > math.MaxInt64-a {
+				// a itself is still a valid, un-saturated term;
+				// only the term after it overflows.
+				next = math.MaxInt64
+			}
+			a, b = b, next
+		}
+		return a
+	}
+}
+
 // Intervals creates a backoff policy that selects the nth duration in the
 // argument list. If the retry counter is greater than the number of
 // items provided, the final item is returned.  If the retry counter
@@ -140,12 +164,27 @@ func Seconds(secs ...int) Strategy {
 // returned by a Strategy. Splay is useful for avoiding "thundering
 // herd" scenarios, where multiple processes become inadvertently
 // synchronized and use the same backoff strategy to use a shared
-// service.
+// service. Splay draws its jitter from the package's default random
+// source; use SplayWith and SetDefaultRand for reproducible jitter in
+// tests.
 func (base Strategy) Splay(duration time.Duration) Strategy {
 	if base == nil {
 		panic("Splay called on nil Strategy")
 	}
-	r := rand.New(rand.NewSource(randint64()))
+	return base.SplayWith(duration, rand.New(rand.NewSource(randint64())))
+}
+
+// SplayWith behaves like Splay, but draws its jitter from r instead
+// of the package's default random source. This makes it possible to
+// get a reproducible sequence of jittered durations out of a Strategy
+// by passing in a *rand.Rand seeded with a known value. The returned
+// Strategy calls r.Int63n and r.Int on every invocation, so it is
+// only as safe for concurrent use as r itself; a *rand.Rand returned
+// by rand.New is not.
+func (base Strategy) SplayWith(duration time.Duration, r *rand.Rand) Strategy {
+	if base == nil {
+		panic("SplayWith called on nil Strategy")
+	}
 	return func(try int) time.Duration {
 		jitter := time.Duration(r.Int63n(int64(duration)))
 		if r.Int()%2 == 0 {
@@ -162,6 +201,22 @@ func (base Strategy) Splay(duration time.Duration) Strategy {
 	}
 }
 
+// SetDefaultRand replaces the package's default source of randomness,
+// used internally by Splay and the other jitter methods whenever they
+// are not given an explicit *rand.Rand. By default, this source is
+// seeded from crypto/rand at package init. Tests that need a
+// reproducible sequence of jittered durations should call
+// SetDefaultRand with a *rand.Rand seeded with a known value before
+// building any Strategies.
+func SetDefaultRand(r *rand.Rand) {
+	if r == nil {
+		panic("SetDefaultRand called with nil *rand.Rand")
+	}
+	randomsrc.Lock()
+	randomsrc.r = r
+	randomsrc.Unlock()
+}
+
 // Scale multiplies all values returned by a fixed duration.
 func (base Strategy) Scale(units time.Duration) Strategy {
 	if base == nil {
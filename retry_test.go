@@ -1,6 +1,8 @@
 package retry
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 	"time"
 )
@@ -48,7 +50,36 @@ func TestFibonacciBackoff(t *testing.T) {
 		t.Errorf("fibonacci backoff(-1) = %s, should be 0ns", backoff(-1))
 	}
 }
+
+func TestFibonacciBackoffSaturation(t *testing.T) {
+	backoff := Fibonacci(1)
+
+	// F(92) is the last Fibonacci number that fits in an int64; it
+	// must be returned exactly, not saturated early just because
+	// F(93) overflows.
+	const f92 = 7540113804746346429
+	if x := backoff(92); x != f92 {
+		t.Errorf("fibonacci backoff(92) = %d, should be %d", x, time.Duration(f92))
+	}
+	if x := backoff(93); x != math.MaxInt64 {
+		t.Errorf("fibonacci backoff(93) = %s, should be %s", x, time.Duration(math.MaxInt64))
+	}
+}
 		
+func TestSplayWithIsReproducible(t *testing.T) {
+	backoff := Exponential(time.Second)
+	r1 := rand.New(rand.NewSource(42))
+	r2 := rand.New(rand.NewSource(42))
+	a := backoff.SplayWith(time.Second/2, r1)
+	b := backoff.SplayWith(time.Second/2, r2)
+
+	for try := 0; try < 10; try++ {
+		if x, y := a(try), b(try); x != y {
+			t.Errorf("SplayWith(%d) = %s, want %s (two Strategies seeded alike should match)", try, x, y)
+		}
+	}
+}
+
 func TestIntervalBackoff(t *testing.T) {
 	ans := []time.Duration{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	backoff := Intervals(ans...)
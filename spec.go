@@ -0,0 +1,279 @@
+package retry
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// A Spec is a declarative, serializable description of a Strategy.
+// Unlike a Strategy, which is an opaque func value, a Spec is plain
+// data that can be stored in a configuration file and used to build
+// a Strategy at runtime, without recompiling the program that uses
+// it.
+type Spec struct {
+	// Kind selects the constructor used to build the base Strategy:
+	// one of "exponential", "fibonacci", "intervals", "seconds" or
+	// "milliseconds".
+	Kind string
+	// Unit is passed to Exponential or Fibonacci when Kind is one
+	// of those two.
+	Unit time.Duration
+	// Values is passed to Intervals, Seconds or Milliseconds when
+	// Kind is one of those three. For "seconds" and "milliseconds",
+	// each value is converted to an integer count of seconds or
+	// milliseconds (by dividing by time.Second or time.Millisecond,
+	// respectively) before being passed to the constructor, so
+	// Values: []time.Duration{2 * time.Second} means the same thing
+	// for Kind "seconds" as it does for Kind "intervals".
+	Values []time.Duration
+	// Wrappers lists, in order, the chained transforms applied to
+	// the base Strategy, such as Scale or Splay.
+	Wrappers []WrapperSpec
+}
+
+// A WrapperSpec describes a single method chained onto a Strategy
+// built from a Spec.
+type WrapperSpec struct {
+	// Kind selects the method to call: one of "scale", "splay",
+	// "min", "max", "shift", "unshift" or "overwrite".
+	Kind string
+	// Values holds the arguments to the method named by Kind. Scale,
+	// Splay, Min and Max take exactly one value; Shift takes one
+	// value, interpreted as a try count rather than a duration;
+	// Unshift and Overwrite take any number of values.
+	Values []time.Duration
+}
+
+// specJSON and wrapperJSON mirror Spec and WrapperSpec, but render
+// durations as strings (e.g. "5s") rather than integer nanosecond
+// counts, so that hand-written configuration files stay readable.
+type specJSON struct {
+	Kind     string        `json:"kind"`
+	Unit     string        `json:"unit,omitempty"`
+	Values   []string      `json:"values,omitempty"`
+	Wrappers []WrapperSpec `json:"wrappers,omitempty"`
+}
+
+type wrapperJSON struct {
+	Kind   string   `json:"kind"`
+	Values []string `json:"values,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Spec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(specJSON{
+		Kind:     s.Kind,
+		Unit:     s.Unit.String(),
+		Values:   durationsToStrings(s.Values),
+		Wrappers: s.Wrappers,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	var raw specJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	values, err := stringsToDurations(raw.Values)
+	if err != nil {
+		return fmt.Errorf("retry: invalid Spec values: %w", err)
+	}
+	var unit time.Duration
+	if raw.Unit != "" {
+		unit, err = time.ParseDuration(raw.Unit)
+		if err != nil {
+			return fmt.Errorf("retry: invalid Spec unit: %w", err)
+		}
+	}
+	s.Kind = raw.Kind
+	s.Unit = unit
+	s.Values = values
+	s.Wrappers = raw.Wrappers
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w WrapperSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrapperJSON{
+		Kind:   w.Kind,
+		Values: durationsToStrings(w.Values),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (w *WrapperSpec) UnmarshalJSON(data []byte) error {
+	var raw wrapperJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	values, err := stringsToDurations(raw.Values)
+	if err != nil {
+		return fmt.Errorf("retry: invalid WrapperSpec values: %w", err)
+	}
+	w.Kind = raw.Kind
+	w.Values = values
+	return nil
+}
+
+func durationsToStrings(dur []time.Duration) []string {
+	if dur == nil {
+		return nil
+	}
+	out := make([]string, len(dur))
+	for i, d := range dur {
+		out[i] = d.String()
+	}
+	return out
+}
+
+func stringsToDurations(s []string) ([]time.Duration, error) {
+	if s == nil {
+		return nil, nil
+	}
+	out := make([]time.Duration, len(s))
+	for i, v := range s {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+// Build constructs the Strategy described by s. It returns an error
+// if s.Kind, or the Kind of one of s.Wrappers, is not recognized, or
+// if a wrapper is given the wrong number of values.
+func (s Spec) Build() (Strategy, error) {
+	var base Strategy
+	switch s.Kind {
+	case "exponential":
+		base = Exponential(s.Unit)
+	case "fibonacci":
+		base = Fibonacci(s.Unit)
+	case "intervals":
+		base = Intervals(s.Values...)
+	case "seconds":
+		base = Seconds(durationsToInts(s.Values, time.Second)...)
+	case "milliseconds":
+		base = Milliseconds(durationsToInts(s.Values, time.Millisecond)...)
+	default:
+		return nil, fmt.Errorf("retry: Spec has unknown kind %q", s.Kind)
+	}
+	for _, w := range s.Wrappers {
+		var err error
+		base, err = w.apply(base)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Wrap base in a closure that captures it, even though the
+	// wrapper adds nothing behaviorally. Some constructors (e.g.
+	// Intervals with no arguments) return a closure literal with no
+	// captured variables of its own, which the compiler is free to
+	// represent as a single shared value; closureAddr would then
+	// collide across every Spec built that way. Capturing base here
+	// guarantees the Strategy returned to the caller, and remembered
+	// by rememberSpec, is backed by a closure allocated fresh for
+	// this call.
+	strategy := Strategy(func(try int) time.Duration { return base(try) })
+	rememberSpec(strategy, s)
+	return strategy, nil
+}
+
+// durationsToInts converts each duration in dur to an integer count
+// of unit, for passing to Seconds or Milliseconds.
+func durationsToInts(dur []time.Duration, unit time.Duration) []int {
+	out := make([]int, len(dur))
+	for i, d := range dur {
+		out[i] = int(d / unit)
+	}
+	return out
+}
+
+func (w WrapperSpec) apply(base Strategy) (Strategy, error) {
+	switch w.Kind {
+	case "scale":
+		if len(w.Values) != 1 {
+			return nil, fmt.Errorf("retry: scale wrapper takes exactly one value, got %d", len(w.Values))
+		}
+		return base.Scale(w.Values[0]), nil
+	case "splay":
+		if len(w.Values) != 1 {
+			return nil, fmt.Errorf("retry: splay wrapper takes exactly one value, got %d", len(w.Values))
+		}
+		return base.Splay(w.Values[0]), nil
+	case "min":
+		if len(w.Values) != 1 {
+			return nil, fmt.Errorf("retry: min wrapper takes exactly one value, got %d", len(w.Values))
+		}
+		return base.Min(w.Values[0]), nil
+	case "max":
+		if len(w.Values) != 1 {
+			return nil, fmt.Errorf("retry: max wrapper takes exactly one value, got %d", len(w.Values))
+		}
+		return base.Max(w.Values[0]), nil
+	case "shift":
+		if len(w.Values) != 1 {
+			return nil, fmt.Errorf("retry: shift wrapper takes exactly one value, got %d", len(w.Values))
+		}
+		return base.Shift(int(w.Values[0])), nil
+	case "unshift":
+		return base.Unshift(w.Values...), nil
+	case "overwrite":
+		return base.Overwrite(w.Values...), nil
+	default:
+		return nil, fmt.Errorf("retry: unknown wrapper kind %q", w.Kind)
+	}
+}
+
+// specRegistry associates Strategies built by (Spec).Build with the
+// Spec that produced them, so Describe can recover it. It is keyed by
+// closureAddr(s), not reflect.ValueOf(s).Pointer(): the latter
+// documents that it returns only "an underlying code pointer" for a
+// func value, which for a closure is the address of the compiled
+// closure *body*, shared by every Strategy built from the same Build
+// call site -- not a per-instance identity. Keying on that collides
+// two Specs of the same Kind and silently returns the wrong one; see
+// closureAddr for the fix.
+//
+// Each entry also keeps a strong reference to the Strategy itself, so
+// its closure can never be garbage collected and have its address
+// reused by an unrelated, later Strategy. The tradeoff is that
+// Describe keeps every Strategy ever built via Spec.Build alive for
+// the life of the program.
+var specRegistry sync.Map // map[uintptr]specRegistryEntry
+
+type specRegistryEntry struct {
+	strategy Strategy
+	spec     Spec
+}
+
+func rememberSpec(s Strategy, spec Spec) {
+	specRegistry.Store(closureAddr(s), specRegistryEntry{strategy: s, spec: spec})
+}
+
+// Describe returns the Spec that, when built, produced s. Describe
+// only recognizes Strategies returned by (Spec).Build; for any other
+// Strategy, including one built by hand from the same constructors
+// and wrapper methods, it returns an error.
+func Describe(s Strategy) (Spec, error) {
+	v, ok := specRegistry.Load(closureAddr(s))
+	if !ok {
+		return Spec{}, fmt.Errorf("retry: Strategy was not constructed by (Spec).Build")
+	}
+	return v.(specRegistryEntry).spec, nil
+}
+
+// closureAddr returns the address of fn's underlying closure. At
+// runtime, a func value is represented as a single pointer to that
+// closure, so reinterpreting fn's own storage as a pointer reads it
+// directly. Unlike reflect.Value.Pointer, the result differs between
+// distinct closures created from the same function literal.
+func closureAddr(fn Strategy) uintptr {
+	return uintptr(*(*unsafe.Pointer)(unsafe.Pointer(&fn)))
+}
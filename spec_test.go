@@ -0,0 +1,151 @@
+package retry
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpecBuild(t *testing.T) {
+	spec := Spec{
+		Kind: "exponential",
+		Unit: time.Second,
+		Wrappers: []WrapperSpec{
+			{Kind: "max", Values: []time.Duration{10 * time.Second}},
+		},
+	}
+	backoff, err := spec.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	if x := backoff(0); x != time.Second {
+		t.Errorf("backoff(0) = %s, want %s", x, time.Second)
+	}
+	if x := backoff(10); x != 10*time.Second {
+		t.Errorf("backoff(10) = %s, want %s", x, 10*time.Second)
+	}
+}
+
+func TestSpecBuildSecondsAndMilliseconds(t *testing.T) {
+	secs, err := (Spec{Kind: "seconds", Values: []time.Duration{2 * time.Second, 4 * time.Second}}).Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	if x := secs(0); x != 2*time.Second {
+		t.Errorf("secs(0) = %s, want %s", x, 2*time.Second)
+	}
+	if x := secs(1); x != 4*time.Second {
+		t.Errorf("secs(1) = %s, want %s", x, 4*time.Second)
+	}
+
+	ms, err := (Spec{Kind: "milliseconds", Values: []time.Duration{2 * time.Millisecond}}).Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	if x := ms(0); x != 2*time.Millisecond {
+		t.Errorf("ms(0) = %s, want %s", x, 2*time.Millisecond)
+	}
+}
+
+func TestSpecBuildUnknownKind(t *testing.T) {
+	if _, err := (Spec{Kind: "nonsense"}).Build(); err == nil {
+		t.Error("Build with unknown Kind should return an error")
+	}
+}
+
+func TestSpecJSONRoundTrip(t *testing.T) {
+	spec := Spec{
+		Kind:   "intervals",
+		Values: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		Wrappers: []WrapperSpec{
+			{Kind: "splay", Values: []time.Duration{time.Millisecond * 500}},
+		},
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal returned %v", err)
+	}
+	var got Spec
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned %v", err)
+	}
+	if !reflect.DeepEqual(spec, got) {
+		t.Errorf("round-tripped Spec = %+v, want %+v", got, spec)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	spec := Spec{Kind: "fibonacci", Unit: time.Millisecond}
+	backoff, err := spec.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	got, err := Describe(backoff)
+	if err != nil {
+		t.Fatalf("Describe returned %v", err)
+	}
+	if !reflect.DeepEqual(spec, got) {
+		t.Errorf("Describe = %+v, want %+v", got, spec)
+	}
+}
+
+func TestDescribeUnknownStrategy(t *testing.T) {
+	if _, err := Describe(Exponential(time.Second)); err == nil {
+		t.Error("Describe of a hand-built Strategy should return an error")
+	}
+}
+
+func TestDescribeDistinguishesEmptyValuesSpecs(t *testing.T) {
+	// Intervals (and Seconds, Milliseconds) with no Values return a
+	// closure literal with no captured variables, which the compiler
+	// may represent as a single shared value across every call.
+	spec1 := Spec{Kind: "intervals"}
+	spec2 := Spec{Kind: "intervals"}
+
+	backoff1, err := spec1.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	backoff2, err := spec2.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+
+	if _, err := Describe(backoff1); err != nil {
+		t.Errorf("Describe(backoff1) returned %v", err)
+	}
+	if _, err := Describe(backoff2); err != nil {
+		t.Errorf("Describe(backoff2) returned %v", err)
+	}
+}
+
+func TestDescribeDistinguishesSameKindSpecs(t *testing.T) {
+	spec1 := Spec{Kind: "fibonacci", Unit: time.Second}
+	spec2 := Spec{Kind: "fibonacci", Unit: time.Hour}
+
+	backoff1, err := spec1.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+	backoff2, err := spec2.Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+
+	got1, err := Describe(backoff1)
+	if err != nil {
+		t.Fatalf("Describe returned %v", err)
+	}
+	if !reflect.DeepEqual(spec1, got1) {
+		t.Errorf("Describe(backoff1) = %+v, want %+v", got1, spec1)
+	}
+
+	got2, err := Describe(backoff2)
+	if err != nil {
+		t.Fatalf("Describe returned %v", err)
+	}
+	if !reflect.DeepEqual(spec2, got2) {
+		t.Errorf("Describe(backoff2) = %+v, want %+v", got2, spec2)
+	}
+}